@@ -0,0 +1,82 @@
+package paginator
+
+// WithRules, WithLimit, WithOrder, After and Before make Paginator an
+// immutable, chainable builder: each call returns a new *Paginator
+// linked to the receiver instead of mutating it. A base paginator can
+// therefore be built once (rules, limit, order) and reused across
+// concurrent requests by chaining request-specific After/Before off of
+// it without racing on shared state, unlike the mutating setters below,
+// which are kept as thin wrappers for backward compatibility.
+//
+// Those setters are only safe to call on a Paginator with no parent,
+// i.e. one built via New() and never passed through With*/After/Before.
+// Calling one directly on a chain link is a mistake: Resolve rebuilds
+// the link from its recorded apply closure alone, so a field set any
+// other way on that link is silently dropped. Callers building on top
+// of a chain (see paginator/relay) should thread state through With*/
+// After/Before and a final Resolve instead.
+
+// WithRules returns a new Paginator with rules set, leaving the
+// receiver untouched.
+func (p *Paginator) WithRules(rules ...Rule) *Paginator {
+	return p.chain(func(n *Paginator) { n.SetRules(rules...) })
+}
+
+// WithLimit returns a new Paginator with limit set, leaving the
+// receiver untouched.
+func (p *Paginator) WithLimit(limit int) *Paginator {
+	return p.chain(func(n *Paginator) { n.SetLimit(limit) })
+}
+
+// WithOrder returns a new Paginator with order set, leaving the
+// receiver untouched.
+func (p *Paginator) WithOrder(order Order) *Paginator {
+	return p.chain(func(n *Paginator) { n.SetOrder(order) })
+}
+
+// After returns a new Paginator seeking after cursor, leaving the
+// receiver untouched.
+func (p *Paginator) After(cursor string) *Paginator {
+	return p.chain(func(n *Paginator) { n.SetAfterCursor(cursor) })
+}
+
+// Before returns a new Paginator seeking before cursor, leaving the
+// receiver untouched.
+func (p *Paginator) Before(cursor string) *Paginator {
+	return p.chain(func(n *Paginator) { n.SetBeforeCursor(cursor) })
+}
+
+func (p *Paginator) chain(apply func(*Paginator)) *Paginator {
+	return &Paginator{parent: p, apply: apply}
+}
+
+// Resolve flattens the chain built by WithRules/WithLimit/WithOrder/
+// After/Before into a single concrete, independent Paginator, applying
+// each link's closure lazily in root-to-leaf order. A paginator with no
+// parent (the common case when the mutating setters are used directly)
+// is returned as-is.
+//
+// Paginate, AppendPaginationQuery, GetCursor and PaginateWithCount all
+// call Resolve internally, so callers only need it directly when they
+// must mutate the result of a chain with a setter before handing it to
+// one of those methods, or call a method that does not resolve on its
+// own (paginator/relay resolves before calling EncodeNodeCursor).
+func (p *Paginator) Resolve() *Paginator {
+	if p.parent == nil {
+		return p
+	}
+	n := p.parent.Resolve().clone()
+	p.apply(n)
+	return n
+}
+
+// clone makes an independent copy of p's concrete state, so resolving a
+// chain never mutates the shared base paginator it branched from.
+func (p *Paginator) clone() *Paginator {
+	c := *p
+	c.parent = nil
+	c.apply = nil
+	c.rules = make([]Rule, len(p.rules))
+	copy(c.rules, p.rules)
+	return &c
+}
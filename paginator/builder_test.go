@@ -0,0 +1,59 @@
+package paginator
+
+import "testing"
+
+func TestResolve_FlattensChain(t *testing.T) {
+	base := New().WithRules(Rule{Key: "a", Order: ASC}).WithLimit(5)
+	after := base.After("cursor-a")
+
+	resolved := after.Resolve()
+	if resolved.limit != 5 {
+		t.Errorf("limit = %d, want 5", resolved.limit)
+	}
+	if len(resolved.rules) != 1 || resolved.rules[0].Key != "a" {
+		t.Errorf("rules = %+v, want a single rule keyed \"a\"", resolved.rules)
+	}
+	if resolved.cursor.After == nil || *resolved.cursor.After != "cursor-a" {
+		t.Errorf("cursor.After = %v, want \"cursor-a\"", resolved.cursor.After)
+	}
+}
+
+func TestResolve_NoParentReturnsReceiver(t *testing.T) {
+	p := New().WithRules(Rule{Key: "a", Order: ASC})
+	resolved := p.Resolve()
+	resolved2 := resolved.Resolve()
+	if resolved2 != resolved {
+		t.Error("Resolve() on a paginator with no parent should return the receiver unchanged")
+	}
+}
+
+// TestResolve_BaseUntouchedAcrossBranches is a regression test for the
+// bug where building per-request state by mutating a shared base
+// paginator in place (rather than chaining and resolving) let one
+// request's state leak into another's. Each branch off the same base
+// must end up with its own independent cursor.
+func TestResolve_BaseUntouchedAcrossBranches(t *testing.T) {
+	base := New().WithRules(Rule{Key: "a", Order: ASC}).WithLimit(5)
+
+	branchA := base.After("cursor-a").Resolve()
+	branchB := base.After("cursor-b").Resolve()
+
+	if *branchA.cursor.After != "cursor-a" {
+		t.Errorf("branchA cursor.After = %v, want \"cursor-a\"", *branchA.cursor.After)
+	}
+	if *branchB.cursor.After != "cursor-b" {
+		t.Errorf("branchB cursor.After = %v, want \"cursor-b\"", *branchB.cursor.After)
+	}
+	if branchA.limit != 5 || branchB.limit != 5 {
+		t.Errorf("both branches should inherit the base limit, got %d and %d", branchA.limit, branchB.limit)
+	}
+}
+
+func TestClone_IndependentRulesSlice(t *testing.T) {
+	p := New().WithRules(Rule{Key: "a", Order: ASC}).Resolve()
+	c := p.clone()
+	c.rules[0].Key = "b"
+	if p.rules[0].Key != "a" {
+		t.Errorf("mutating the clone's rules mutated the original: p.rules[0].Key = %q, want \"a\"", p.rules[0].Key)
+	}
+}
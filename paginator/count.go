@@ -0,0 +1,152 @@
+package paginator
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/getmiferia/gorm-cursor-paginator/v2/internal/util"
+)
+
+// CountStrategy selects how PaginateWithCount computes PageInfo.TotalCount.
+type CountStrategy int
+
+const (
+	// CountCapped issues `SELECT COUNT(*) FROM (<query> LIMIT cap+1) t`,
+	// so it only ever scans cap+1 rows regardless of table size.
+	// TotalCount is exact when the table holds cap or fewer matching
+	// rows, and cap+1 (meaning "more than cap") otherwise. This is the
+	// default so PaginateWithCount stays O(page size) rather than
+	// O(table size).
+	CountCapped CountStrategy = iota
+	// CountExact issues an exact `SELECT COUNT(*)` against the base
+	// statement with ORDER BY, LIMIT and OFFSET stripped.
+	CountExact
+	// CountEstimated reads a Postgres-only row estimate from
+	// pg_class.reltuples, which is near-instant on very large tables but
+	// can be stale between ANALYZEs. Falls back to CountExact on other
+	// dialects.
+	CountEstimated
+)
+
+// defaultCountCap is the cap CountCapped uses when none is set via
+// SetCountCap.
+const defaultCountCap = 1000
+
+// PageInfo augments a page of cursor-paginated results with a total
+// count and next/previous availability, as returned by
+// PaginateWithCount.
+type PageInfo struct {
+	TotalCount      *int64
+	TotalPages      *int64
+	HasNextPage     bool
+	HasPreviousPage bool
+}
+
+// SetCountStrategy sets the strategy PaginateWithCount uses to compute
+// PageInfo.TotalCount. Defaults to CountCapped.
+func (p *Paginator) SetCountStrategy(strategy CountStrategy) {
+	p.countStrategy = strategy
+}
+
+// SetCountCap sets the cap CountCapped counts up to before giving up and
+// reporting "more than cap". Defaults to 1000.
+func (p *Paginator) SetCountCap(cap int) {
+	p.countCap = cap
+}
+
+// PaginateWithCount behaves like Paginate but additionally computes
+// PageInfo.TotalCount using the configured CountStrategy, and reports
+// HasNextPage/HasPreviousPage derived from the limit+1 probe Paginate
+// already performs, without a second query for those two fields. In
+// page mode (SetPage) they come from the page number and TotalPages
+// instead, so they are only as exact as the strategy's TotalCount.
+func (p *Paginator) PaginateWithCount(db *gorm.DB, dest interface{}) (result *gorm.DB, c Cursor, info PageInfo, err error) {
+	p = p.Resolve()
+	if result, c, err = p.Paginate(db, dest); err != nil {
+		return
+	}
+	if info.TotalCount, err = p.count(db, dest); err != nil {
+		return
+	}
+	if info.TotalCount != nil && p.limit > 0 {
+		pages := (*info.TotalCount + int64(p.limit) - 1) / int64(p.limit)
+		info.TotalPages = &pages
+	}
+	info.HasNextPage, info.HasPreviousPage = p.derivePageInfo(c, info.TotalPages)
+	return
+}
+
+// derivePageInfo reports HasNextPage/HasPreviousPage from the limit+1
+// probe Paginate already performed (c), without a second query; in page
+// mode it uses the page number and totalPages instead.
+func (p *Paginator) derivePageInfo(c Cursor, totalPages *int64) (hasNext, hasPrevious bool) {
+	switch {
+	case p.isPageMode():
+		// Cursor.After/Before are always populated in page mode (see
+		// EncodeCursor), so they can't signal "more data" here the way
+		// they do for cursor-mode paging; derive from the page number
+		// and total pages instead.
+		hasPrevious = p.page > 1
+		if totalPages != nil {
+			hasNext = int64(p.page) < *totalPages
+		}
+	case p.isBackward():
+		hasPrevious = c.Before != nil
+		hasNext = p.cursor.Before != nil
+	default:
+		hasNext = c.After != nil
+		hasPrevious = p.cursor.After != nil
+	}
+	return
+}
+
+func (p *Paginator) count(db *gorm.DB, dest interface{}) (*int64, error) {
+	switch p.countStrategy {
+	case CountExact:
+		return p.countExact(db, dest)
+	case CountEstimated:
+		if db.Dialector.Name() == "postgres" {
+			return p.countEstimated(db, dest)
+		}
+		return p.countExact(db, dest)
+	default:
+		return p.countCapped(db, dest)
+	}
+}
+
+func (p *Paginator) countExact(db *gorm.DB, dest interface{}) (*int64, error) {
+	var count int64
+	stmt := db.Session(&gorm.Session{}).Model(dest).Order("").Limit(-1).Offset(-1)
+	if err := stmt.Count(&count).Error; err != nil {
+		return nil, err
+	}
+	return &count, nil
+}
+
+func (p *Paginator) countCapped(db *gorm.DB, dest interface{}) (*int64, error) {
+	cap := p.countCap
+	if cap <= 0 {
+		cap = defaultCountCap
+	}
+	base := db.Session(&gorm.Session{}).Model(dest).Order("").Limit(cap + 1).Offset(-1).Select("1")
+	var count int64
+	err := db.Session(&gorm.Session{}).Table("(?) t", base).Count(&count).Error
+	if err != nil {
+		return nil, err
+	}
+	return &count, nil
+}
+
+func (p *Paginator) countEstimated(db *gorm.DB, dest interface{}) (*int64, error) {
+	schema, err := util.ParseSchema(db, dest)
+	if err != nil {
+		return nil, err
+	}
+	var estimate int64
+	err = db.Session(&gorm.Session{}).
+		Raw("SELECT reltuples::bigint FROM pg_class WHERE relname = ?", schema.Table).
+		Scan(&estimate).Error
+	if err != nil {
+		return nil, err
+	}
+	return &estimate, nil
+}
@@ -0,0 +1,188 @@
+package paginator
+
+import (
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func ptrInt64(i int64) *int64 { return &i }
+
+func TestDerivePageInfo_CursorMode(t *testing.T) {
+	after := "a"
+	before := "b"
+
+	t.Run("first page, more data ahead", func(t *testing.T) {
+		p := &Paginator{}
+		hasNext, hasPrev := p.derivePageInfo(Cursor{After: &after}, nil)
+		if !hasNext || hasPrev {
+			t.Errorf("hasNext=%v hasPrev=%v, want true,false", hasNext, hasPrev)
+		}
+	})
+
+	t.Run("first page, no more data", func(t *testing.T) {
+		p := &Paginator{}
+		hasNext, hasPrev := p.derivePageInfo(Cursor{}, nil)
+		if hasNext || hasPrev {
+			t.Errorf("hasNext=%v hasPrev=%v, want false,false", hasNext, hasPrev)
+		}
+	})
+
+	t.Run("subsequent forward page, more data ahead", func(t *testing.T) {
+		p := &Paginator{cursor: Cursor{After: &after}}
+		hasNext, hasPrev := p.derivePageInfo(Cursor{After: &after}, nil)
+		if !hasNext || !hasPrev {
+			t.Errorf("hasNext=%v hasPrev=%v, want true,true", hasNext, hasPrev)
+		}
+	})
+
+	t.Run("subsequent forward page, last page", func(t *testing.T) {
+		p := &Paginator{cursor: Cursor{After: &after}}
+		hasNext, hasPrev := p.derivePageInfo(Cursor{}, nil)
+		if hasNext || !hasPrev {
+			t.Errorf("hasNext=%v hasPrev=%v, want false,true", hasNext, hasPrev)
+		}
+	})
+
+	t.Run("backward, more data before", func(t *testing.T) {
+		p := &Paginator{cursor: Cursor{Before: &before}}
+		hasNext, hasPrev := p.derivePageInfo(Cursor{Before: &before}, nil)
+		if !hasNext || !hasPrev {
+			t.Errorf("hasNext=%v hasPrev=%v, want true,true", hasNext, hasPrev)
+		}
+	})
+
+	t.Run("backward, reached the true beginning", func(t *testing.T) {
+		p := &Paginator{cursor: Cursor{Before: &before}}
+		hasNext, hasPrev := p.derivePageInfo(Cursor{}, nil)
+		if !hasNext || hasPrev {
+			t.Errorf("hasNext=%v hasPrev=%v, want true,false", hasNext, hasPrev)
+		}
+	})
+}
+
+func TestDerivePageInfo_PageMode(t *testing.T) {
+	cases := []struct {
+		name            string
+		page            uint
+		totalPages      *int64
+		wantHasNext     bool
+		wantHasPrevious bool
+	}{
+		{"first of many pages", 1, ptrInt64(5), true, false},
+		{"middle page", 3, ptrInt64(5), true, true},
+		{"last page", 5, ptrInt64(5), false, true},
+		{"unknown total", 2, nil, false, true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			p := &Paginator{page: c.page}
+			hasNext, hasPrev := p.derivePageInfo(Cursor{}, c.totalPages)
+			if hasNext != c.wantHasNext || hasPrev != c.wantHasPrevious {
+				t.Errorf("hasNext=%v hasPrev=%v, want %v,%v", hasNext, hasPrev, c.wantHasNext, c.wantHasPrevious)
+			}
+		})
+	}
+}
+
+type countTestItem struct {
+	ID int
+}
+
+func newCountTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&countTestItem{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	for i := 1; i <= 5; i++ {
+		if err := db.Create(&countTestItem{ID: i}).Error; err != nil {
+			t.Fatalf("failed to seed row %d: %v", i, err)
+		}
+	}
+	return db
+}
+
+// TestPaginateWithCount walks all 5 seeded rows two at a time, checking
+// TotalCount/TotalPages and HasNextPage/HasPreviousPage at each step
+// against a real *gorm.DB.
+func TestPaginateWithCount(t *testing.T) {
+	db := newCountTestDB(t)
+
+	p1 := New().WithRules(Rule{Key: "ID", Order: ASC}).WithLimit(2).Resolve()
+	var page1 []countTestItem
+	_, c1, info1, err := p1.PaginateWithCount(db, &page1)
+	if err != nil {
+		t.Fatalf("PaginateWithCount() error = %v", err)
+	}
+	if len(page1) != 2 {
+		t.Fatalf("got %d rows, want 2", len(page1))
+	}
+	if info1.TotalCount == nil || *info1.TotalCount != 5 {
+		t.Fatalf("TotalCount = %v, want 5", info1.TotalCount)
+	}
+	if info1.TotalPages == nil || *info1.TotalPages != 3 {
+		t.Fatalf("TotalPages = %v, want 3", info1.TotalPages)
+	}
+	if !info1.HasNextPage || info1.HasPreviousPage {
+		t.Errorf("page 1: HasNextPage=%v HasPreviousPage=%v, want true,false", info1.HasNextPage, info1.HasPreviousPage)
+	}
+
+	p2 := New().WithRules(Rule{Key: "ID", Order: ASC}).WithLimit(2).After(*c1.After).Resolve()
+	var page2 []countTestItem
+	_, c2, info2, err := p2.PaginateWithCount(db, &page2)
+	if err != nil {
+		t.Fatalf("PaginateWithCount() error = %v", err)
+	}
+	if !info2.HasNextPage || !info2.HasPreviousPage {
+		t.Errorf("page 2: HasNextPage=%v HasPreviousPage=%v, want true,true", info2.HasNextPage, info2.HasPreviousPage)
+	}
+
+	p3 := New().WithRules(Rule{Key: "ID", Order: ASC}).WithLimit(2).After(*c2.After).Resolve()
+	var page3 []countTestItem
+	_, _, info3, err := p3.PaginateWithCount(db, &page3)
+	if err != nil {
+		t.Fatalf("PaginateWithCount() error = %v", err)
+	}
+	if len(page3) != 1 {
+		t.Fatalf("got %d rows on final page, want 1", len(page3))
+	}
+	if info3.HasNextPage || !info3.HasPreviousPage {
+		t.Errorf("page 3: HasNextPage=%v HasPreviousPage=%v, want false,true", info3.HasNextPage, info3.HasPreviousPage)
+	}
+}
+
+func TestPaginateWithCount_CountExact(t *testing.T) {
+	db := newCountTestDB(t)
+	p := New().WithRules(Rule{Key: "ID", Order: ASC}).WithLimit(2).Resolve()
+	p.SetCountStrategy(CountExact)
+	var page []countTestItem
+	_, _, info, err := p.PaginateWithCount(db, &page)
+	if err != nil {
+		t.Fatalf("PaginateWithCount() error = %v", err)
+	}
+	if info.TotalCount == nil || *info.TotalCount != 5 {
+		t.Fatalf("TotalCount = %v, want 5", info.TotalCount)
+	}
+}
+
+func TestPaginateWithCount_CountCappedRespectsCap(t *testing.T) {
+	db := newCountTestDB(t)
+	p := New().WithRules(Rule{Key: "ID", Order: ASC}).WithLimit(2).Resolve()
+	p.SetCountStrategy(CountCapped)
+	p.SetCountCap(3)
+	var page []countTestItem
+	_, _, info, err := p.PaginateWithCount(db, &page)
+	if err != nil {
+		t.Fatalf("PaginateWithCount() error = %v", err)
+	}
+	// 5 actual rows against a cap of 3: CountCapped reports cap+1, not
+	// the exact count, once the table holds more than the cap.
+	if info.TotalCount == nil || *info.TotalCount != 4 {
+		t.Fatalf("TotalCount = %v, want 4 (cap+1)", info.TotalCount)
+	}
+}
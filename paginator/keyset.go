@@ -0,0 +1,101 @@
+package paginator
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// KeysetMode controls how Paginator builds the predicate used to seek
+// past the last returned row.
+type KeysetMode int
+
+const (
+	// KeysetOrExpansion builds the predicate as the OR-expanded form
+	// `a > ? OR a = ? AND b > ? OR ...`. This is the default, and the
+	// only form every dialect understands, but most planners cannot turn
+	// it into a single index range scan once there is more than one sort
+	// key.
+	KeysetOrExpansion KeysetMode = iota
+	// KeysetRowValue builds the predicate as the SQL/92 row-value form
+	// `(a, b, c) > (?, ?, ?)`, which lets the planner walk a composite
+	// index in a single range scan. Paginator falls back to
+	// KeysetOrExpansion automatically when the rules don't all share the
+	// same direction, any rule has a NULLReplacement or SQLType, or the
+	// dialect doesn't support row-value comparisons.
+	KeysetRowValue
+)
+
+// rowValueDialects lists the dialects Paginator trusts to support SQL/92
+// row-value comparisons. MySQL only gained it in 8.0, so the plain
+// "mysql" dialect name is deliberately absent here — setup() upgrades it
+// to "mysql8" after confirming the server version, and anything it
+// can't confirm stays on "mysql" and falls back to KeysetOrExpansion.
+// SQL Server still doesn't support it at all.
+var rowValueDialects = map[string]bool{
+	"postgres": true,
+	"mysql8":   true,
+	"sqlite":   true,
+}
+
+// resolveMySQLDialect probes the connected server's version and returns
+// "mysql8" when it's 8.0 or newer, or the original "mysql" name
+// otherwise (including when the version can't be determined), so
+// canUseRowValueKeyset falls back safely.
+func resolveMySQLDialect(db *gorm.DB) string {
+	var version string
+	if err := db.Raw("SELECT VERSION()").Scan(&version).Error; err != nil {
+		return "mysql"
+	}
+	var major int
+	if _, err := fmt.Sscanf(version, "%d.", &major); err != nil || major < 8 {
+		return "mysql"
+	}
+	return "mysql8"
+}
+
+// canUseRowValueKeyset reports whether rules can be expressed as a single
+// row-value comparison instead of the OR-expanded predicate.
+func canUseRowValueKeyset(rules []Rule, dialect string) bool {
+	if !rowValueDialects[dialect] {
+		return false
+	}
+	if len(rules) == 0 {
+		return false
+	}
+	order := rules[0].Order
+	for _, rule := range rules {
+		if rule.Order != order {
+			return false
+		}
+		if rule.NULLReplacement != nil || rule.SQLType != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// buildRowValueCursorSQLQuery builds the `(a, b, c) > (?, ?, ?)` form of
+// the keyset predicate for rules that all share the same direction.
+func buildRowValueCursorSQLQuery(rules []Rule, forward bool) string {
+	reprs := make([]string, len(rules))
+	for i, rule := range rules {
+		reprs[i] = rule.SQLRepr
+	}
+	operator := "<"
+	if (forward && rules[0].Order == ASC) || (!forward && rules[0].Order == DESC) {
+		operator = ">"
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(rules)), ", ")
+	return fmt.Sprintf("(%s) %s (%s)", strings.Join(reprs, ", "), operator, placeholders)
+}
+
+// buildRowValueCursorSQLQueryArgs builds the args for
+// buildRowValueCursorSQLQuery, one per rule instead of the triangular
+// `len(rules)*(len(rules)+1)/2` the OR-expanded form needs.
+func buildRowValueCursorSQLQueryArgs(fields []interface{}) []interface{} {
+	args := make([]interface{}, len(fields))
+	copy(args, fields)
+	return args
+}
@@ -0,0 +1,221 @@
+package paginator
+
+import (
+	"strings"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestCanUseRowValueKeyset(t *testing.T) {
+	cases := []struct {
+		name    string
+		rules   []Rule
+		dialect string
+		want    bool
+	}{
+		{
+			name:    "supported dialect, same direction",
+			rules:   []Rule{{Key: "a", Order: ASC, SQLRepr: "a"}, {Key: "b", Order: ASC, SQLRepr: "b"}},
+			dialect: "postgres",
+			want:    true,
+		},
+		{
+			name:    "mixed directions falls back",
+			rules:   []Rule{{Key: "a", Order: ASC, SQLRepr: "a"}, {Key: "b", Order: DESC, SQLRepr: "b"}},
+			dialect: "postgres",
+			want:    false,
+		},
+		{
+			name:    "NULLReplacement falls back",
+			rules:   []Rule{{Key: "a", Order: ASC, SQLRepr: "a", NULLReplacement: "x"}},
+			dialect: "postgres",
+			want:    false,
+		},
+		{
+			name:    "unsupported dialect falls back",
+			rules:   []Rule{{Key: "a", Order: ASC, SQLRepr: "a"}},
+			dialect: "sqlserver",
+			want:    false,
+		},
+		{
+			name:    "unconfirmed mysql falls back",
+			rules:   []Rule{{Key: "a", Order: ASC, SQLRepr: "a"}},
+			dialect: "mysql",
+			want:    false,
+		},
+		{
+			name:    "confirmed mysql8 is allowed",
+			rules:   []Rule{{Key: "a", Order: ASC, SQLRepr: "a"}},
+			dialect: "mysql8",
+			want:    true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := canUseRowValueKeyset(c.rules, c.dialect); got != c.want {
+				t.Errorf("canUseRowValueKeyset() = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestBuildRowValueCursorSQLQuery(t *testing.T) {
+	rules := []Rule{
+		{Key: "a", Order: ASC, SQLRepr: "a"},
+		{Key: "b", Order: ASC, SQLRepr: "b"},
+		{Key: "c", Order: ASC, SQLRepr: "c"},
+	}
+	got := buildRowValueCursorSQLQuery(rules, true)
+	want := "(a, b, c) > (?, ?, ?)"
+	if got != want {
+		t.Errorf("buildRowValueCursorSQLQuery() = %q, want %q", got, want)
+	}
+}
+
+func TestBuildRowValueCursorSQLQuery_Backward(t *testing.T) {
+	rules := []Rule{{Key: "a", Order: ASC, SQLRepr: "a"}, {Key: "b", Order: ASC, SQLRepr: "b"}}
+	forward := buildRowValueCursorSQLQuery(rules, true)
+	backward := buildRowValueCursorSQLQuery(rules, false)
+	if !strings.Contains(forward, ">") {
+		t.Errorf("forward query should use >, got %q", forward)
+	}
+	if !strings.Contains(backward, "<") {
+		t.Errorf("backward query should use <, got %q", backward)
+	}
+}
+
+func TestBuildRowValueCursorSQLQueryArgs(t *testing.T) {
+	fields := []interface{}{1, 2, 3, 4}
+	args := buildRowValueCursorSQLQueryArgs(fields)
+	if len(args) != len(fields) {
+		t.Fatalf("got %d args, want %d (row-value form takes one per rule, not the triangular count the OR-expanded form needs)", len(args), len(fields))
+	}
+}
+
+// TestRowValueKeyset_ReducedArgsAndQueryLength covers the request's ask
+// for a comparison against the OR-expanded form at 4+ sort keys: the
+// row-value form should need exactly one arg per rule instead of the
+// triangular len*(len+1)/2, and produce a visibly shorter query.
+func TestRowValueKeyset_ReducedArgsAndQueryLength(t *testing.T) {
+	rules := make([]Rule, 4)
+	fields := make([]interface{}, 4)
+	for i := range rules {
+		key := string(rune('a' + i))
+		rules[i] = Rule{Key: key, Order: ASC, SQLRepr: key}
+		fields[i] = i
+	}
+	p := &Paginator{rules: rules, keysetMode: KeysetRowValue, dialect: "postgres"}
+	rowValueQuery := p.BuildCursorSQLQuery()
+	rowValueArgs := p.BuildCursorSQLQueryArgs(fields)
+
+	p.keysetMode = KeysetOrExpansion
+	orQuery := p.BuildCursorSQLQuery()
+	orArgs := p.BuildCursorSQLQueryArgs(fields)
+
+	if len(rowValueArgs) != len(rules) {
+		t.Errorf("row-value args = %d, want %d", len(rowValueArgs), len(rules))
+	}
+	triangular := len(rules) * (len(rules) + 1) / 2
+	if len(orArgs) != triangular {
+		t.Errorf("OR-expansion args = %d, want %d", len(orArgs), triangular)
+	}
+	if len(rowValueQuery) >= len(orQuery) {
+		t.Errorf("row-value query (%d chars) should be shorter than OR-expansion query (%d chars)", len(rowValueQuery), len(orQuery))
+	}
+}
+
+func fourRules() []Rule {
+	rules := make([]Rule, 4)
+	for i := range rules {
+		key := string(rune('a' + i))
+		rules[i] = Rule{Key: key, Order: ASC, SQLRepr: key}
+	}
+	return rules
+}
+
+func BenchmarkBuildCursorSQLQuery_RowValue(b *testing.B) {
+	p := &Paginator{rules: fourRules(), keysetMode: KeysetRowValue, dialect: "postgres"}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.BuildCursorSQLQuery()
+	}
+}
+
+func BenchmarkBuildCursorSQLQuery_OrExpansion(b *testing.B) {
+	p := &Paginator{rules: fourRules()}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.BuildCursorSQLQuery()
+	}
+}
+
+type keysetTieItem struct {
+	ID int
+	A  int
+	B  int
+}
+
+func newKeysetTieTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&keysetTieItem{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	rows := []keysetTieItem{{ID: 1, A: 1, B: 10}, {ID: 2, A: 1, B: 20}, {ID: 3, A: 1, B: 30}, {ID: 4, A: 2, B: 5}, {ID: 5, A: 2, B: 15}}
+	for i := range rows {
+		if err := db.Create(&rows[i]).Error; err != nil {
+			t.Fatalf("failed to seed row %+v: %v", rows[i], err)
+		}
+	}
+	return db
+}
+
+// TestRowValueKeyset_TiesMatchOrExpansion proves the row-value predicate
+// produces the same result set as the OR-expanded form at a tie on the
+// leading sort key, against a real DB rather than just the generated
+// SQL strings.
+func TestRowValueKeyset_TiesMatchOrExpansion(t *testing.T) {
+	db := newKeysetTieTestDB(t)
+
+	rowValueBase := New().WithRules(Rule{Key: "A", Order: ASC}, Rule{Key: "B", Order: ASC}).WithLimit(2).Resolve()
+	rowValueBase.SetKeysetMode(KeysetRowValue)
+	var firstPage []keysetTieItem
+	_, c, err := rowValueBase.Paginate(db, &firstPage)
+	if err != nil {
+		t.Fatalf("Paginate() error = %v", err)
+	}
+	if len(firstPage) != 2 || firstPage[0].B != 10 || firstPage[1].B != 20 {
+		t.Fatalf("unexpected first page %+v", firstPage)
+	}
+
+	rowValueRest := New().WithRules(Rule{Key: "A", Order: ASC}, Rule{Key: "B", Order: ASC}).WithLimit(10).After(*c.After).Resolve()
+	rowValueRest.SetKeysetMode(KeysetRowValue)
+	var rowValueResult []keysetTieItem
+	if _, _, err := rowValueRest.Paginate(db, &rowValueResult); err != nil {
+		t.Fatalf("Paginate() error = %v", err)
+	}
+
+	orExpansionRest := New().WithRules(Rule{Key: "A", Order: ASC}, Rule{Key: "B", Order: ASC}).WithLimit(10).After(*c.After).Resolve()
+	var orExpansionResult []keysetTieItem
+	if _, _, err := orExpansionRest.Paginate(db, &orExpansionResult); err != nil {
+		t.Fatalf("Paginate() error = %v", err)
+	}
+
+	want := []keysetTieItem{{ID: 3, A: 1, B: 30}, {ID: 4, A: 2, B: 5}, {ID: 5, A: 2, B: 15}}
+	if len(rowValueResult) != len(want) || len(orExpansionResult) != len(want) {
+		t.Fatalf("got %d row-value rows and %d OR-expansion rows, want %d", len(rowValueResult), len(orExpansionResult), len(want))
+	}
+	for i := range want {
+		if rowValueResult[i] != want[i] {
+			t.Errorf("row-value row %d = %+v, want %+v", i, rowValueResult[i], want[i])
+		}
+		if orExpansionResult[i] != want[i] {
+			t.Errorf("OR-expansion row %d = %+v, want %+v", i, orExpansionResult[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package paginator
+
+import "errors"
+
+// ErrMixedPaginationMode is returned when a page number is set alongside
+// an after/before cursor on the same call. The two navigation modes are
+// mutually exclusive.
+var ErrMixedPaginationMode = errors.New("paginator: cannot mix page number with after/before cursor")
+
+// SetPage switches Paginator into offset mode for page n (1-indexed),
+// e.g. for admin tables or exports that need jump-to-page navigation.
+// Paginate then emits `LIMIT limit+1 OFFSET (n-1)*limit` (the extra row
+// is the usual has-more probe) instead of the cursor predicate, while still returning a Cursor so callers can switch
+// back to cursor mode from any page. Leave unset to keep the default
+// cursor-based behavior.
+func (p *Paginator) SetPage(n uint) {
+	p.page = n
+}
+
+func (p *Paginator) isPageMode() bool {
+	return p.page > 0
+}
@@ -0,0 +1,55 @@
+package paginator
+
+import "testing"
+
+func TestSetPage_IsPageMode(t *testing.T) {
+	p := &Paginator{}
+	if p.isPageMode() {
+		t.Fatal("isPageMode() should be false before SetPage")
+	}
+	p.SetPage(3)
+	if !p.isPageMode() {
+		t.Fatal("isPageMode() should be true after SetPage")
+	}
+}
+
+func TestValidate_MixedPaginationMode(t *testing.T) {
+	after := "cursor"
+	p := &Paginator{
+		rules:  []Rule{{Key: "a", Order: ASC, SQLRepr: "a"}},
+		limit:  10,
+		order:  ASC,
+		page:   2,
+		cursor: Cursor{After: &after},
+	}
+	if err := p.validate(nil, nil); err != ErrMixedPaginationMode {
+		t.Errorf("validate() = %v, want ErrMixedPaginationMode", err)
+	}
+}
+
+func TestSqlPaginatorValidate_MixedPaginationMode(t *testing.T) {
+	after := "cursor"
+	p := &SqlPaginator{}
+	p.rules = []Rule{{Key: "a", Order: ASC, SQLRepr: "a"}}
+	p.limit = 10
+	p.order = ASC
+	p.page = 2
+	p.cursor = Cursor{After: &after}
+	if err := p.validate(); err != ErrMixedPaginationMode {
+		t.Errorf("validate() = %v, want ErrMixedPaginationMode", err)
+	}
+}
+
+func TestSqlPaginatorAppendPagingQuery_PageMode(t *testing.T) {
+	p := &SqlPaginator{}
+	p.rules = []Rule{{Key: "a", Order: ASC, SQLRepr: "a"}}
+	p.limit = 10
+	p.order = ASC
+	p.SetPage(3)
+
+	got := p.AppendPagingQuery(nil)
+	want := " ORDER BY a ASC LIMIT 11 OFFSET 20"
+	if got != want {
+		t.Errorf("AppendPagingQuery() = %q, want %q", got, want)
+	}
+}
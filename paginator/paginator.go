@@ -22,10 +22,18 @@ func New(opts ...Option) *Paginator {
 
 // Paginator a builder doing pagination
 type Paginator struct {
-	cursor Cursor
-	rules  []Rule
-	limit  int
-	order  Order
+	cursor        Cursor
+	rules         []Rule
+	limit         int
+	order         Order
+	keysetMode    KeysetMode
+	dialect       string
+	countStrategy CountStrategy
+	countCap      int
+	page          uint
+
+	parent *Paginator
+	apply  func(*Paginator)
 }
 
 // SetRules sets paging rules
@@ -55,6 +63,21 @@ func (p *Paginator) SetOrder(order Order) {
 	p.order = order
 }
 
+// SetKeysetMode sets how the keyset predicate is built. Defaults to
+// KeysetOrExpansion; pass KeysetRowValue to opt into the SQL/92
+// row-value form when the rules and dialect support it.
+func (p *Paginator) SetKeysetMode(mode KeysetMode) {
+	p.keysetMode = mode
+}
+
+// SetDialect overrides the dialect used to decide whether the row-value
+// keyset form is supported. Paginator otherwise detects it from
+// db.Dialector.Name() the first time it builds a query, upgrading
+// "mysql" to "mysql8" after confirming the server is 8.0+.
+func (p *Paginator) SetDialect(dialect string) {
+	p.dialect = dialect
+}
+
 // SetAfterCursor sets paging after cursor
 func (p *Paginator) SetAfterCursor(afterCursor string) {
 	p.cursor.After = &afterCursor
@@ -67,6 +90,7 @@ func (p *Paginator) SetBeforeCursor(beforeCursor string) {
 
 // Paginate paginates data
 func (p *Paginator) Paginate(db *gorm.DB, dest interface{}) (result *gorm.DB, c Cursor, err error) {
+	p = p.Resolve()
 	if err = p.validate(db, dest); err != nil {
 		return
 	}
@@ -100,6 +124,7 @@ func (p *Paginator) Paginate(db *gorm.DB, dest interface{}) (result *gorm.DB, c
 
 // AppendPaginationQuery appends pagination query to db
 func (p *Paginator) AppendPaginationQuery(db *gorm.DB, dest interface{}) (result *gorm.DB, c Cursor, err error) {
+	p = p.Resolve()
 	if err = p.validate(db, dest); err != nil {
 		return
 	}
@@ -116,8 +141,27 @@ func (p *Paginator) AppendPaginationQuery(db *gorm.DB, dest interface{}) (result
 	return
 }
 
+// Reversed returns a new Paginator with every rule's effective sort
+// order flipped, leaving the receiver untouched. It's for callers (such
+// as paginator/relay) that need to fetch the tail of a result set
+// without an explicit before-cursor to seek from: query in the flipped
+// order, trim/reverse the result back to display order, same as
+// isBackward() does internally for an explicit before-cursor.
+func (p *Paginator) Reversed() *Paginator {
+	p = p.Resolve()
+	n := p.clone()
+	n.order = p.order.flip()
+	for i, rule := range n.rules {
+		if rule.Order != "" {
+			n.rules[i].Order = rule.Order.flip()
+		}
+	}
+	return n
+}
+
 // GetCursor  gets new cursor from dest
 func (p *Paginator) GetCursor(dest interface{}) (c Cursor, err error) {
+	p = p.Resolve()
 	// dest must be a pointer type or gorm will panic above
 	elems := reflect.ValueOf(dest).Elem()
 	// only encode next cursor when elems is not empty slice
@@ -148,6 +192,9 @@ func (p *Paginator) validate(db *gorm.DB, dest interface{}) (err error) {
 	if err = p.order.validate(); err != nil {
 		return
 	}
+	if p.isPageMode() && (p.cursor.After != nil || p.cursor.Before != nil) {
+		return ErrMixedPaginationMode
+	}
 	for _, rule := range p.rules {
 		if err = rule.validate(db, dest); err != nil {
 			return
@@ -157,6 +204,15 @@ func (p *Paginator) validate(db *gorm.DB, dest interface{}) (err error) {
 }
 
 func (p *Paginator) setup(db *gorm.DB, dest interface{}) error {
+	if p.dialect == "" {
+		p.dialect = db.Dialector.Name()
+	}
+	// MySQL only gained row-value comparisons in 8.0: the dialect name
+	// alone can't tell 8.0 from 5.7, so confirm the server version
+	// before trusting KeysetRowValue on it.
+	if p.keysetMode == KeysetRowValue && p.dialect == "mysql" {
+		p.dialect = resolveMySQLDialect(db)
+	}
 	var sqlTable string
 	for i := range p.rules {
 		rule := &p.rules[i]
@@ -237,7 +293,9 @@ func (p *Paginator) AppendPagingQuery(db *gorm.DB, fields []interface{}) *gorm.D
 	stmt := db
 	stmt = stmt.Limit(p.limit + 1)
 	stmt = stmt.Order(p.BuildOrderSQL())
-	if len(fields) > 0 {
+	if p.isPageMode() {
+		stmt = stmt.Offset(int(p.page-1) * p.limit)
+	} else if len(fields) > 0 {
 		stmt = stmt.Where(
 			p.BuildCursorSQLQuery(),
 			p.BuildCursorSQLQueryArgs(fields)...,
@@ -259,6 +317,9 @@ func (p *Paginator) BuildOrderSQL() string {
 }
 
 func (p *Paginator) BuildCursorSQLQuery() string {
+	if p.keysetMode == KeysetRowValue && canUseRowValueKeyset(p.rules, p.dialect) {
+		return buildRowValueCursorSQLQuery(p.rules, p.isForward())
+	}
 	queries := make([]string, len(p.rules))
 	query := ""
 	for i, rule := range p.rules {
@@ -276,16 +337,25 @@ func (p *Paginator) BuildCursorSQLQuery() string {
 }
 
 func (p *Paginator) BuildCursorSQLQueryArgs(fields []interface{}) (args []interface{}) {
+	if p.keysetMode == KeysetRowValue && canUseRowValueKeyset(p.rules, p.dialect) {
+		return buildRowValueCursorSQLQueryArgs(fields)
+	}
 	for i := 1; i <= len(fields); i++ {
 		args = append(args, fields[:i]...)
 	}
 	return
 }
 
+// EncodeNodeCursor encodes the cursor for a single node, e.g. for
+// building a per-edge cursor in a Relay Cursor Connection.
+func (p *Paginator) EncodeNodeCursor(node reflect.Value) (string, error) {
+	return cursor.NewEncoder(p.getEncoderFields()).Encode(node)
+}
+
 func (p *Paginator) EncodeCursor(elems reflect.Value, hasMore bool) (result Cursor, err error) {
 	encoder := cursor.NewEncoder(p.getEncoderFields())
 	// encode after cursor
-	if p.isBackward() || hasMore {
+	if p.isBackward() || hasMore || p.isPageMode() {
 		c, err := encoder.Encode(elems.Index(elems.Len() - 1))
 		if err != nil {
 			return Cursor{}, err
@@ -293,7 +363,7 @@ func (p *Paginator) EncodeCursor(elems reflect.Value, hasMore bool) (result Curs
 		result.After = &c
 	}
 	// encode before cursor
-	if p.isForward() || (hasMore && p.isBackward()) {
+	if p.isForward() || (hasMore && p.isBackward()) || p.isPageMode() {
 		c, err := encoder.Encode(elems.Index(0))
 		if err != nil {
 			return Cursor{}, err
@@ -2,8 +2,6 @@ package paginator
 
 import (
 	"fmt"
-	"github.com/getmiferia/gorm-cursor-paginator/v2/cursor"
-	"reflect"
 	"strings"
 )
 
@@ -22,50 +20,81 @@ func NewSqlPaginator(opts ...Option) *SqlPaginator {
 	return p
 }
 
-// SqlPaginator a builder doing pagination
+// SqlPaginator a builder doing pagination. It embeds Paginator and
+// shares all of its query-building code (SetRules/SetLimit/..., the
+// cursor codec, BuildOrderSQL, BuildCursorSQLQuery, keyset handling):
+// SqlPaginator only overrides what genuinely differs — producing a raw
+// SQL string instead of chaining onto a *gorm.DB.
 type SqlPaginator struct {
 	Paginator
+
+	parent *SqlPaginator
+	apply  func(*SqlPaginator)
 }
 
-// SetRules sets paging rules
-func (p *SqlPaginator) SetRules(rules ...Rule) {
-	p.rules = make([]Rule, len(rules))
-	copy(p.rules, rules)
+// WithRules returns a new SqlPaginator with rules set, leaving the
+// receiver untouched. See Paginator.WithRules for the rationale.
+func (p *SqlPaginator) WithRules(rules ...Rule) *SqlPaginator {
+	return p.chain(func(n *SqlPaginator) { n.SetRules(rules...) })
 }
 
-// SetKeys sets paging keys
-func (p *SqlPaginator) SetKeys(keys ...string) {
-	rules := make([]Rule, len(keys))
-	for i, key := range keys {
-		rules[i] = Rule{
-			Key: key,
-		}
-	}
-	p.SetRules(rules...)
+// WithLimit returns a new SqlPaginator with limit set, leaving the
+// receiver untouched.
+func (p *SqlPaginator) WithLimit(limit int) *SqlPaginator {
+	return p.chain(func(n *SqlPaginator) { n.SetLimit(limit) })
 }
 
-// SetLimit sets paging limit
-func (p *SqlPaginator) SetLimit(limit int) {
-	p.limit = limit
+// WithOrder returns a new SqlPaginator with order set, leaving the
+// receiver untouched.
+func (p *SqlPaginator) WithOrder(order Order) *SqlPaginator {
+	return p.chain(func(n *SqlPaginator) { n.SetOrder(order) })
 }
 
-// SetOrder sets paging order
-func (p *SqlPaginator) SetOrder(order Order) {
-	p.order = order
+// After returns a new SqlPaginator seeking after cursor, leaving the
+// receiver untouched.
+func (p *SqlPaginator) After(cursor string) *SqlPaginator {
+	return p.chain(func(n *SqlPaginator) { n.SetAfterCursor(cursor) })
 }
 
-// SetAfterCursor sets paging after cursor
-func (p *SqlPaginator) SetAfterCursor(afterCursor string) {
-	p.cursor.After = &afterCursor
+// Before returns a new SqlPaginator seeking before cursor, leaving the
+// receiver untouched.
+func (p *SqlPaginator) Before(cursor string) *SqlPaginator {
+	return p.chain(func(n *SqlPaginator) { n.SetBeforeCursor(cursor) })
 }
 
-// SetBeforeCursor sets paging before cursor
-func (p *SqlPaginator) SetBeforeCursor(beforeCursor string) {
-	p.cursor.Before = &beforeCursor
+func (p *SqlPaginator) chain(apply func(*SqlPaginator)) *SqlPaginator {
+	return &SqlPaginator{parent: p, apply: apply}
+}
+
+// Resolve flattens the chain built by WithRules/WithLimit/WithOrder/
+// After/Before into a single concrete, independent SqlPaginator,
+// applying each link's closure lazily in root-to-leaf order.
+func (p *SqlPaginator) Resolve() *SqlPaginator {
+	if p.parent == nil {
+		return p
+	}
+	n := p.parent.Resolve().clone()
+	p.apply(n)
+	return n
+}
+
+// clone makes an independent copy of p's concrete state, so resolving a
+// chain never mutates the shared base paginator it branched from.
+func (p *SqlPaginator) clone() *SqlPaginator {
+	c := *p
+	c.parent = nil
+	c.apply = nil
+	c.rules = make([]Rule, len(p.rules))
+	copy(c.rules, p.rules)
+	return &c
 }
 
 // AppendPaginationQuery appends pagination query to db
 func (p *SqlPaginator) AppendPaginationQuery(dest interface{}) (result string, err error) {
+	p = p.Resolve()
+	if err = p.validate(); err != nil {
+		return
+	}
 	fields, err := p.DecodeCursor(dest)
 	if err != nil {
 		return
@@ -83,57 +112,30 @@ func (p *SqlPaginator) GetPaginationQuery(dest interface{}) (string, error) {
 	return sql, nil
 }
 
-// GetCursor  gets new cursor from dest
-func (p *SqlPaginator) GetCursor(dest interface{}) (c Cursor, err error) {
-	// dest must be a pointer type or gorm will panic above
-	elems := reflect.ValueOf(dest).Elem()
-	// only encode next cursor when elems is not empty slice
-	if elems.Kind() == reflect.Slice && elems.Len() > 0 {
-		hasMore := elems.Len() > p.limit
-		if hasMore {
-			elems.Set(elems.Slice(0, elems.Len()-1))
-		}
-		if p.isBackward() {
-			elems.Set(reverse(elems))
-		}
-		if c, err = p.EncodeCursor(elems, hasMore); err != nil {
-			return
-		}
+// validate checks the parts of Paginator.validate that don't require a
+// *gorm.DB: SqlPaginator builds raw SQL against rules whose SQLRepr the
+// caller has already filled in, so it never calls Paginator.setup either.
+func (p *SqlPaginator) validate() error {
+	if len(p.rules) == 0 {
+		return ErrNoRule
 	}
-	return
-}
-
-func (p *SqlPaginator) DecodeCursor(dest interface{}) (result []interface{}, err error) {
-	if p.isForward() {
-		if result, err = cursor.NewDecoder(p.getDecoderFields()).Decode(*p.cursor.After, dest); err != nil {
-			err = ErrInvalidCursor
-		}
-	} else if p.isBackward() {
-		if result, err = cursor.NewDecoder(p.getDecoderFields()).Decode(*p.cursor.Before, dest); err != nil {
-			err = ErrInvalidCursor
-		}
+	if p.limit <= 0 {
+		return ErrInvalidLimit
 	}
-	// replace null values
-	for i := range result {
-		if isNil(result[i]) {
-			result[i] = p.rules[i].NULLReplacement
-		}
+	if err := p.order.validate(); err != nil {
+		return err
 	}
-	return
-}
-
-func (p *SqlPaginator) isForward() bool {
-	return p.cursor.After != nil
-}
-
-func (p *SqlPaginator) isBackward() bool {
-	// forward take precedence over backward
-	return !p.isForward() && p.cursor.Before != nil
+	if p.isPageMode() && (p.cursor.After != nil || p.cursor.Before != nil) {
+		return ErrMixedPaginationMode
+	}
+	return nil
 }
 
 func (p *SqlPaginator) AppendPagingQuery(fields []interface{}) string {
 	stmt := ""
-	if len(fields) > 0 {
+	if p.isPageMode() {
+		// no predicate: the OFFSET below substitutes for the cursor one
+	} else if len(fields) > 0 {
 		q := p.BuildCursorSQLQuery()
 		args := p.BuildCursorSQLQueryArgs(fields)
 		q = ExplainSQL(q, nil, `'`, args...)
@@ -141,85 +143,8 @@ func (p *SqlPaginator) AppendPagingQuery(fields []interface{}) string {
 	}
 	stmt = fmt.Sprintf("%s ORDER BY %s", stmt, p.BuildOrderSQL())
 	stmt = fmt.Sprintf("%s LIMIT %d", stmt, p.limit+1)
-	return stmt
-}
-
-func (p *SqlPaginator) BuildOrderSQL() string {
-	orders := make([]string, len(p.rules))
-	for i, rule := range p.rules {
-		order := rule.Order
-		if p.isBackward() {
-			order = order.flip()
-		}
-		orders[i] = fmt.Sprintf("%s %s", rule.SQLRepr, order)
-	}
-	return strings.Join(orders, ", ")
-}
-
-func (p *SqlPaginator) BuildCursorSQLQuery() string {
-	queries := make([]string, len(p.rules))
-	query := ""
-	for i, rule := range p.rules {
-		operator := "<"
-		if (p.isForward() && rule.Order == ASC) ||
-			(p.isBackward() && rule.Order == DESC) {
-			operator = ">"
-		}
-		queries[i] = fmt.Sprintf("%s%s %s ?", query, rule.SQLRepr, operator)
-		query = fmt.Sprintf("%s%s = ? AND ", query, rule.SQLRepr)
-	}
-	// for exmaple:
-	// a > 1 OR a = 1 AND b > 2 OR a = 1 AND b = 2 AND c > 3
-	return strings.Join(queries, " OR ")
-}
-
-func (p *SqlPaginator) BuildCursorSQLQueryArgs(fields []interface{}) (args []interface{}) {
-	for i := 1; i <= len(fields); i++ {
-		args = append(args, fields[:i]...)
-	}
-	return
-}
-
-func (p *SqlPaginator) EncodeCursor(elems reflect.Value, hasMore bool) (result Cursor, err error) {
-	encoder := cursor.NewEncoder(p.getEncoderFields())
-	// encode after cursor
-	if p.isBackward() || hasMore {
-		c, err := encoder.Encode(elems.Index(elems.Len() - 1))
-		if err != nil {
-			return Cursor{}, err
-		}
-		result.After = &c
-	}
-	// encode before cursor
-	if p.isForward() || (hasMore && p.isBackward()) {
-		c, err := encoder.Encode(elems.Index(0))
-		if err != nil {
-			return Cursor{}, err
-		}
-		result.Before = &c
-	}
-	return
-}
-
-/* custom types */
-func (p *SqlPaginator) getEncoderFields() []cursor.EncoderField {
-	fields := make([]cursor.EncoderField, len(p.rules))
-	for i, rule := range p.rules {
-		fields[i].Key = rule.Key
-		if rule.CustomType != nil {
-			fields[i].Meta = rule.CustomType.Meta
-		}
+	if p.isPageMode() {
+		stmt = fmt.Sprintf("%s OFFSET %d", stmt, int(p.page-1)*p.limit)
 	}
-	return fields
-}
-
-func (p *SqlPaginator) getDecoderFields() []cursor.DecoderField {
-	fields := make([]cursor.DecoderField, len(p.rules))
-	for i, rule := range p.rules {
-		fields[i].Key = rule.Key
-		if rule.CustomType != nil {
-			fields[i].Type = &rule.CustomType.Type
-		}
-	}
-	return fields
+	return stmt
 }
@@ -0,0 +1,190 @@
+// Package relay adapts paginator.Paginator to the GraphQL Cursor
+// Connections spec (https://relay.dev/graphql/connections.htm) so it can
+// back a Relay-compliant resolver directly. It depends only on the core
+// paginator package, not on any GraphQL library.
+package relay
+
+import (
+	"errors"
+	"reflect"
+
+	"gorm.io/gorm"
+
+	"github.com/getmiferia/gorm-cursor-paginator/v2/paginator"
+)
+
+// ErrBothFirstAndLast is returned when Args sets both First and Last,
+// which the spec forbids.
+var ErrBothFirstAndLast = errors.New("relay: first and last cannot both be set")
+
+// ErrNegativeLimit is returned when First or Last is negative.
+var ErrNegativeLimit = errors.New("relay: first/last must not be negative")
+
+// Args are the Relay Cursor Connections arguments as defined by the spec.
+type Args struct {
+	First  *int
+	Last   *int
+	After  *string
+	Before *string
+}
+
+// Edge is a single Relay edge: a node plus the cursor pointing at it.
+type Edge[T any] struct {
+	Node   T
+	Cursor string
+}
+
+// PageInfo mirrors the Relay Cursor Connections PageInfo type.
+type PageInfo struct {
+	StartCursor     *string
+	EndCursor       *string
+	HasNextPage     bool
+	HasPreviousPage bool
+}
+
+// Connection is a Relay Cursor Connection: a page of edges plus PageInfo.
+type Connection[T any] struct {
+	Edges    []Edge[T]
+	PageInfo PageInfo
+}
+
+// defaultMaxLimit caps First/Last when the caller doesn't configure one,
+// guarding against unbounded page sizes.
+const defaultMaxLimit = 100
+
+// Option configures how Paginate maps Args onto the underlying Paginator.
+type Option func(*config)
+
+type config struct {
+	maxLimit int
+}
+
+// WithMaxLimit caps First/Last at max instead of the default 100.
+func WithMaxLimit(max int) Option {
+	return func(c *config) {
+		c.maxLimit = max
+	}
+}
+
+// Paginate runs p against db using Relay-style args and returns a
+// Connection. p must already have its rules/order configured, and is
+// never mutated: Paginate derives the per-request First/Last/After/Before
+// state via p.WithLimit/After/Before, so p itself — including a base
+// paginator built once and reused across concurrent requests — is safe
+// to share between calls.
+//
+// Last together with After pages forward from After like First does:
+// it returns the first Last rows after the cursor, not the last ones.
+func Paginate[T any](p *paginator.Paginator, db *gorm.DB, dest *[]T, args Args, opts ...Option) (*Connection[T], error) {
+	cfg := config{maxLimit: defaultMaxLimit}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if err := validate(args); err != nil {
+		return nil, err
+	}
+
+	limit := cfg.maxLimit
+	switch {
+	case args.First != nil:
+		limit = *args.First
+	case args.Last != nil:
+		limit = *args.Last
+	}
+	if limit > cfg.maxLimit {
+		limit = cfg.maxLimit
+	}
+
+	// Build the per-request paginator via the chain API rather than the
+	// mutating setters: p may itself be a base paginator built once via
+	// WithRules/WithLimit and reused across concurrent requests, and
+	// mutating it in place here would either race on shared state or,
+	// for a paginator assembled purely through chaining, silently no-op
+	// (the setter would be lost the next time p is resolved).
+	backward := args.Last != nil
+	// tail is Last with neither After nor Before: the only case that
+	// takes the Reversed() path below.
+	tail := backward && args.After == nil && args.Before == nil
+	rp := p.WithLimit(limit)
+	switch {
+	case args.After != nil:
+		rp = rp.After(*args.After)
+	case args.Before != nil:
+		rp = rp.Before(*args.Before)
+	case backward:
+		// Last with no Before: there's no boundary row to seek from, so
+		// fetch the tail of the result set by flipping the sort order
+		// instead and reversing the rows back below, mirroring what
+		// Paginator.isBackward() does internally for an explicit
+		// before-cursor.
+		rp = rp.Reversed()
+	}
+	rp = rp.Resolve()
+
+	_, c, err := rp.Paginate(db, dest)
+	if err != nil {
+		return nil, err
+	}
+	if tail {
+		reverseSlice(*dest)
+	}
+
+	nodes := reflect.ValueOf(*dest)
+	edges := make([]Edge[T], nodes.Len())
+	for i := 0; i < nodes.Len(); i++ {
+		cur, err := rp.EncodeNodeCursor(nodes.Index(i))
+		if err != nil {
+			return nil, err
+		}
+		edges[i] = Edge[T]{Node: (*dest)[i], Cursor: cur}
+	}
+
+	conn := &Connection[T]{Edges: edges}
+	if len(edges) > 0 {
+		conn.PageInfo.StartCursor = &edges[0].Cursor
+		conn.PageInfo.EndCursor = &edges[len(edges)-1].Cursor
+	}
+
+	// Derived from the limit+1 probe already performed by Paginate (c)
+	// plus which argument the caller supplied, per the spec's guidance
+	// for implementations that don't want to issue a second query.
+	switch {
+	case backward && args.Before != nil:
+		conn.PageInfo.HasPreviousPage = c.Before != nil
+		conn.PageInfo.HasNextPage = args.Before != nil
+	case tail:
+		// Last with no Before: rp was built via Reversed(), so isBackward()
+		// never triggered Paginator's own backward cursor encoding and c.Before
+		// is never set; c.After carries the hasMore signal from the flipped
+		// (descending) probe instead, meaning "more data further back".
+		// There's nothing "after" the true tail we just fetched.
+		conn.PageInfo.HasPreviousPage = c.After != nil
+		conn.PageInfo.HasNextPage = false
+	default:
+		conn.PageInfo.HasNextPage = c.After != nil
+		conn.PageInfo.HasPreviousPage = args.After != nil
+	}
+	return conn, nil
+}
+
+// reverseSlice reverses s in place, used to restore display order after
+// fetching "the last N" via a flipped sort order instead of a real
+// before-cursor (see Reversed()).
+func reverseSlice[T any](s []T) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+func validate(args Args) error {
+	if args.First != nil && args.Last != nil {
+		return ErrBothFirstAndLast
+	}
+	if args.First != nil && *args.First < 0 {
+		return ErrNegativeLimit
+	}
+	if args.Last != nil && *args.Last < 0 {
+		return ErrNegativeLimit
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package relay
+
+import (
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/getmiferia/gorm-cursor-paginator/v2/paginator"
+)
+
+func TestValidate(t *testing.T) {
+	first, last, neg := 10, 10, -1
+	cases := []struct {
+		name string
+		args Args
+		want error
+	}{
+		{"first only", Args{First: &first}, nil},
+		{"last only", Args{Last: &last}, nil},
+		{"neither", Args{}, nil},
+		{"both first and last", Args{First: &first, Last: &last}, ErrBothFirstAndLast},
+		{"negative first", Args{First: &neg}, ErrNegativeLimit},
+		{"negative last", Args{Last: &neg}, ErrNegativeLimit},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if err := validate(c.args); err != c.want {
+				t.Errorf("validate() = %v, want %v", err, c.want)
+			}
+		})
+	}
+}
+
+func TestWithMaxLimit(t *testing.T) {
+	cfg := config{maxLimit: defaultMaxLimit}
+	WithMaxLimit(10)(&cfg)
+	if cfg.maxLimit != 10 {
+		t.Errorf("maxLimit = %d, want 10", cfg.maxLimit)
+	}
+}
+
+type relayTestItem struct {
+	ID int
+}
+
+func newRelayTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&relayTestItem{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	for i := 1; i <= 5; i++ {
+		if err := db.Create(&relayTestItem{ID: i}).Error; err != nil {
+			t.Fatalf("failed to seed row %d: %v", i, err)
+		}
+	}
+	return db
+}
+
+// TestPaginate_LastWithoutBefore is a regression test for the bug where
+// Args{Last: N} with no Before cursor fell through to the default
+// forward path, returning the first N rows instead of the last N.
+func TestPaginate_LastWithoutBefore(t *testing.T) {
+	db := newRelayTestDB(t)
+	base := paginator.New().WithRules(paginator.Rule{Key: "ID", Order: paginator.ASC}).WithLimit(10)
+
+	last := 2
+	var page []relayTestItem
+	conn, err := Paginate(base, db, &page, Args{Last: &last})
+	if err != nil {
+		t.Fatalf("Paginate() error = %v", err)
+	}
+	if len(conn.Edges) != 2 || conn.Edges[0].Node.ID != 4 || conn.Edges[1].Node.ID != 5 {
+		got := make([]int, len(conn.Edges))
+		for i, e := range conn.Edges {
+			got[i] = e.Node.ID
+		}
+		t.Fatalf("got IDs %v, want [4 5]", got)
+	}
+	if conn.PageInfo.HasNextPage {
+		t.Error("HasNextPage should be false: this is the true end of the result set")
+	}
+	if !conn.PageInfo.HasPreviousPage {
+		t.Error("HasPreviousPage should be true: rows 1-3 still precede this page")
+	}
+
+	// Paging further back from here must use the real Before path and
+	// pick up right where the Last-only page left off.
+	var prevPage []relayTestItem
+	prevConn, err := Paginate(base, db, &prevPage, Args{Last: &last, Before: conn.PageInfo.StartCursor})
+	if err != nil {
+		t.Fatalf("Paginate() error = %v", err)
+	}
+	if len(prevConn.Edges) != 2 || prevConn.Edges[0].Node.ID != 2 || prevConn.Edges[1].Node.ID != 3 {
+		got := make([]int, len(prevConn.Edges))
+		for i, e := range prevConn.Edges {
+			got[i] = e.Node.ID
+		}
+		t.Fatalf("got IDs %v, want [2 3]", got)
+	}
+}
+
+// TestPaginate_ChainedBasePaginator is a regression test for the bug
+// where Paginate mutated the caller-supplied Paginator directly via
+// SetLimit/SetAfterCursor/SetBeforeCursor: a base built once via
+// WithRules/WithLimit and reused across requests must come out of
+// every call with its own state untouched, and each call must page
+// independently off it.
+func TestPaginate_ChainedBasePaginator(t *testing.T) {
+	db := newRelayTestDB(t)
+	base := paginator.New().WithRules(paginator.Rule{Key: "ID", Order: paginator.ASC}).WithLimit(10)
+
+	first := 2
+	var page1 []relayTestItem
+	conn1, err := Paginate(base, db, &page1, Args{First: &first})
+	if err != nil {
+		t.Fatalf("Paginate() error = %v", err)
+	}
+	if len(conn1.Edges) != 2 {
+		t.Fatalf("got %d edges, want 2", len(conn1.Edges))
+	}
+	if !conn1.PageInfo.HasNextPage {
+		t.Fatal("expected HasNextPage on the first page")
+	}
+
+	var page2 []relayTestItem
+	conn2, err := Paginate(base, db, &page2, Args{First: &first, After: conn1.PageInfo.EndCursor})
+	if err != nil {
+		t.Fatalf("Paginate() error = %v", err)
+	}
+	if len(conn2.Edges) != 2 {
+		t.Fatalf("got %d edges, want 2", len(conn2.Edges))
+	}
+	if conn2.Edges[0].Node.ID != page1[len(page1)-1].ID+1 {
+		t.Errorf("second page should continue right after the first, got ID %d", conn2.Edges[0].Node.ID)
+	}
+
+	// Reusing the same base for an unrelated request must not see the
+	// After cursor threaded through the call above: if Paginate had
+	// mutated base in place, this would resume from page2 instead of
+	// starting over.
+	var fresh []relayTestItem
+	connFresh, err := Paginate(base, db, &fresh, Args{First: &first})
+	if err != nil {
+		t.Fatalf("Paginate() error = %v", err)
+	}
+	if connFresh.Edges[0].Node.ID != page1[0].ID {
+		t.Errorf("reusing base should start from the beginning again, got ID %d", connFresh.Edges[0].Node.ID)
+	}
+}